@@ -0,0 +1,38 @@
+package image
+
+import (
+	"image"
+	"image/color"
+)
+
+type ARGB8888 struct {
+	Pix    []uint8
+	Stride int
+	Rect   image.Rectangle
+}
+
+func (p *ARGB8888) Bounds() image.Rectangle { return p.Rect }
+func (p *ARGB8888) ColorModel() color.Model { return color.RGBAModel }
+func (p *ARGB8888) PixOffset(x, y int) int  { return y*p.Stride + x*4 }
+
+func (p *ARGB8888) Set(x, y int, c color.Color) {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return
+	}
+	i := p.PixOffset(x, y)
+	c1 := color.RGBAModel.Convert(c).(color.RGBA)
+	p.Pix[i+0] = c1.B
+	p.Pix[i+1] = c1.G
+	p.Pix[i+2] = c1.R
+	p.Pix[i+3] = c1.A
+}
+
+func (p *ARGB8888) At(x, y int) color.Color {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return color.RGBA{}
+	}
+	i := p.PixOffset(x, y)
+	return color.RGBA{p.Pix[i+2], p.Pix[i+1], p.Pix[i+0], p.Pix[i+3]}
+}
+
+func (p *ARGB8888) Bytes() ([]uint8, int) { return p.Pix, p.Stride }
@@ -0,0 +1,43 @@
+package image
+
+import (
+	"fmt"
+
+	"github.com/NeowayLabs/drm/mode"
+)
+
+// BlankLevel mirrors the fbdev FBIOBLANK levels.
+type BlankLevel int
+
+const (
+	BlankUnblank BlankLevel = iota
+	BlankNormal
+	BlankVSyncSuspend
+	BlankHSyncSuspend
+	BlankPowerdown
+)
+
+// dpmsValue maps a BlankLevel onto the values of the DRM "DPMS"
+// connector property (DRM_MODE_DPMS_ON/STANDBY/SUSPEND/OFF).
+func (l BlankLevel) dpmsValue() uint64 {
+	switch l {
+	case BlankUnblank:
+		return 0 // DRM_MODE_DPMS_ON
+	case BlankNormal:
+		return 1 // DRM_MODE_DPMS_STANDBY
+	case BlankVSyncSuspend, BlankHSyncSuspend:
+		return 2 // DRM_MODE_DPMS_SUSPEND
+	default:
+		return 3 // DRM_MODE_DPMS_OFF
+	}
+}
+
+// Blank sets the display's power state via the connector's DPMS
+// property, giving DRM-backed applications the same blanking controls
+// fbdev apps get from FBIOBLANK.
+func (d *DRMImage) Blank(level BlankLevel) error {
+	if err := mode.SetConnectorProperty(d.file, d.connID, "DPMS", level.dpmsValue()); err != nil {
+		return fmt.Errorf("failed to set DPMS state for connector %d: %s", d.connID, err.Error())
+	}
+	return nil
+}
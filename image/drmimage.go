@@ -0,0 +1,50 @@
+package image
+
+import (
+	"fmt"
+	"image/draw"
+	"os"
+
+	"github.com/NeowayLabs/drm/mode"
+)
+
+// DRMImage is the draw.Image returned by NewDRMImage. It owns the DRM
+// fd and the framebuffer backing the embedded pixel image, and
+// restores the display to its pre-existing state on Close().
+type DRMImage struct {
+	draw.Image
+	file        *os.File
+	fb          *Framebuffer
+	savedCrtc   mode.Crtc
+	appliedMode mode.ModeInfo
+	crtcID      uint32
+	connID      uint32
+}
+
+// Bytes exposes the underlying pixel memory and stride, when the
+// embedded pixel image supports it, so callers like the remote server
+// can read or write the framebuffer in bulk.
+func (d *DRMImage) Bytes() ([]uint8, int) {
+	if raw, ok := d.Image.(RawImage); ok {
+		return raw.Bytes()
+	}
+	return nil, 0
+}
+
+// Close restores the CRTC that was active before NewDRMImage took over
+// the display, tears down the framebuffer (munmap, RmFB, DestroyDumb),
+// and closes the DRM fd. Without this the console is left unusable
+// after the program exits.
+func (d *DRMImage) Close() error {
+	var firstErr error
+	if err := mode.SetCrtc(d.file, d.crtcID, d.savedCrtc.FbID, d.savedCrtc.X, d.savedCrtc.Y, &d.connID, 1, &d.savedCrtc.Mode); err != nil {
+		firstErr = fmt.Errorf("failed to restore CRTC for connector %d: %s", d.connID, err.Error())
+	}
+	if err := d.fb.Close(d.file); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	if err := d.file.Close(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
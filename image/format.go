@@ -0,0 +1,68 @@
+package image
+
+import (
+	"image"
+	"image/draw"
+)
+
+// RawImage is a draw.Image that also exposes its backing pixel memory
+// directly, so callers that already know its PixelFormat can read or
+// write it in bulk instead of going through Set/At per pixel.
+type RawImage interface {
+	draw.Image
+	// Bytes returns the image's pixel memory and its stride, in bytes.
+	Bytes() ([]uint8, int)
+}
+
+// PixelFormat identifies the pixel layout of a framebuffer using the
+// same fourcc encoding DRM/KMS uses (see drm_fourcc.h).
+type PixelFormat uint32
+
+func fourcc(a, b, c, d byte) PixelFormat {
+	return PixelFormat(a) | PixelFormat(b)<<8 | PixelFormat(c)<<16 | PixelFormat(d)<<24
+}
+
+// Supported pixel formats, named after their DRM fourcc codes.
+const (
+	FormatXRGB8888 PixelFormat = fourcc('X', 'R', '2', '4')
+	FormatARGB8888 PixelFormat = fourcc('A', 'R', '2', '4')
+	FormatRGB565   PixelFormat = fourcc('R', 'G', '1', '6')
+	FormatBGR565   PixelFormat = fourcc('B', 'G', '1', '6')
+)
+
+// bpp returns the bits per pixel CreateFB needs to allocate a dumb
+// buffer large enough for the format.
+func (f PixelFormat) bpp() uint32 {
+	switch f {
+	case FormatXRGB8888, FormatARGB8888:
+		return 32
+	case FormatRGB565, FormatBGR565:
+		return 16
+	default:
+		return 32
+	}
+}
+
+// BytesPerPixel returns how many bytes one pixel of the format occupies
+// in memory, for callers (e.g. the remote package) that need to compute
+// offsets into a raw pixel buffer themselves.
+func (f PixelFormat) BytesPerPixel() int {
+	return int(f.bpp() / 8)
+}
+
+// NewPixelImage wraps pix as a RawImage of the given format, stride and
+// bounds. It lets callers holding raw pixel memory of a known format
+// (e.g. a mirrored remote framebuffer) reuse the same Set/At logic the
+// DRM-backed images use.
+func NewPixelImage(format PixelFormat, pix []uint8, stride int, rect image.Rectangle) RawImage {
+	switch format {
+	case FormatXRGB8888:
+		return &XRGB8888{Pix: pix, Stride: stride, Rect: rect}
+	case FormatARGB8888:
+		return &ARGB8888{Pix: pix, Stride: stride, Rect: rect}
+	case FormatRGB565:
+		return &RGB565{Pix: pix, Stride: stride, Rect: rect}
+	default:
+		return &BGR565{Pix: pix, Stride: stride, Rect: rect}
+	}
+}
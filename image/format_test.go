@@ -0,0 +1,49 @@
+package image
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestXRGB8888SetAt(t *testing.T) {
+	p := &XRGB8888{Pix: make([]uint8, 4*4), Stride: 4, Rect: image.Rect(0, 0, 1, 1)}
+	want := color.RGBA{0x11, 0x22, 0x33, 0xff}
+	p.Set(0, 0, want)
+	if got := p.At(0, 0); got != want {
+		t.Errorf("At() = %v, want %v", got, want)
+	}
+}
+
+func TestARGB8888SetAt(t *testing.T) {
+	p := &ARGB8888{Pix: make([]uint8, 4*4), Stride: 4, Rect: image.Rect(0, 0, 1, 1)}
+	want := color.RGBA{0x11, 0x22, 0x33, 0x80}
+	p.Set(0, 0, want)
+	if got := p.At(0, 0); got != want {
+		t.Errorf("At() = %v, want %v", got, want)
+	}
+}
+
+func TestRGB565SetAt(t *testing.T) {
+	p := &RGB565{Pix: make([]uint8, 2*4), Stride: 2, Rect: image.Rect(0, 0, 1, 1)}
+	want := color.NRGBA{0xf8, 0xfc, 0x00, 0xff}
+	p.Set(0, 0, want)
+	if got := p.At(0, 0); got != want {
+		t.Errorf("At() = %v, want %v", got, want)
+	}
+	if p.Pix[1]>>3 != 0x1f {
+		t.Errorf("RGB565 should pack red into the high byte's top bits, got %#x", p.Pix[1])
+	}
+}
+
+func TestBGR565SetAt(t *testing.T) {
+	p := &BGR565{Pix: make([]uint8, 2*4), Stride: 2, Rect: image.Rect(0, 0, 1, 1)}
+	want := color.NRGBA{0xf8, 0xfc, 0x00, 0xff}
+	p.Set(0, 0, want)
+	if got := p.At(0, 0); got != want {
+		t.Errorf("At() = %v, want %v", got, want)
+	}
+	if p.Pix[1]>>3 != 0x00 {
+		t.Errorf("BGR565 should pack blue into the high byte's top bits, got %#x", p.Pix[1])
+	}
+}
@@ -0,0 +1,82 @@
+package image
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"os"
+
+	"github.com/NeowayLabs/drm/mode"
+	"launchpad.net/gommap"
+)
+
+type Framebuffer struct {
+	id     uint32
+	handle uint32
+	data   []byte
+	fb     *mode.FB
+	size   uint64
+	stride uint32
+	format PixelFormat
+}
+
+func createFramebuffer(file *os.File, dev *mode.Modeset, format PixelFormat) (*Framebuffer, error) {
+	fb, err := mode.CreateFB(file, dev.Width, dev.Height, format.bpp())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create framebuffer: %s", err.Error())
+	}
+	stride := fb.Pitch
+	size := fb.Size
+	handle := fb.Handle
+
+	handles := [4]uint32{handle, 0, 0, 0}
+	pitches := [4]uint32{stride, 0, 0, 0}
+	offsets := [4]uint32{0, 0, 0, 0}
+
+	fbID, err := mode.AddFB2(file, dev.Width, dev.Height, uint32(format), handles, pitches, offsets)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create dumb buffer: %s", err.Error())
+	}
+
+	offset, err := mode.MapDumb(file, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	mmap, err := gommap.MapAt(0, uintptr(file.Fd()), int64(offset), int64(size), gommap.PROT_READ|gommap.PROT_WRITE, gommap.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mmap framebuffer: %s", err.Error())
+	}
+
+	for i := uint64(0); i < size; i++ {
+		mmap[i] = 0
+	}
+
+	return &Framebuffer{
+		id:     fbID,
+		handle: handle,
+		data:   mmap,
+		fb:     fb,
+		size:   size,
+		stride: stride,
+		format: format,
+	}, nil
+}
+
+func (f *Framebuffer) image(width, height int) draw.Image {
+	return NewPixelImage(f.format, f.data, int(f.stride), image.Rect(0, 0, width, height))
+}
+
+func (f *Framebuffer) Close(file *os.File) error {
+	var firstErr error
+	if err := gommap.MMap(f.data).UnsafeUnmap(); err != nil {
+		firstErr = fmt.Errorf("failed to munmap framebuffer: %s", err.Error())
+	}
+	if err := mode.RmFB(file, f.id); err != nil && firstErr == nil {
+		firstErr = fmt.Errorf("failed to remove framebuffer: %s", err.Error())
+	}
+	if err := mode.DestroyDumb(file, f.handle); err != nil && firstErr == nil {
+		firstErr = fmt.Errorf("failed to destroy dumb buffer: %s", err.Error())
+	}
+	return firstErr
+}
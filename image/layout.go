@@ -0,0 +1,39 @@
+package image
+
+import "image"
+
+type LayoutMode int
+
+const (
+	LayoutSideBySide LayoutMode = iota
+	LayoutStacked
+	LayoutCustom
+)
+
+type Layout struct {
+	Mode LayoutMode
+	// Rects is only consulted when Mode is LayoutCustom, one entry per
+	// connected modeset in NewSimpleModeset's order.
+	Rects []image.Rectangle
+}
+
+func (l Layout) rects(sizes []image.Point) ([]image.Rectangle, error) {
+	if l.Mode == LayoutCustom {
+		if len(l.Rects) != len(sizes) {
+			return nil, errInvalidLayout
+		}
+		return l.Rects, nil
+	}
+
+	out := make([]image.Rectangle, len(sizes))
+	var x, y int
+	for i, size := range sizes {
+		out[i] = image.Rect(x, y, x+size.X, y+size.Y)
+		if l.Mode == LayoutStacked {
+			y += size.Y
+		} else {
+			x += size.X
+		}
+	}
+	return out, nil
+}
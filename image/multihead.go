@@ -0,0 +1,183 @@
+package image
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"os"
+
+	"github.com/NeowayLabs/drm"
+	"github.com/NeowayLabs/drm/mode"
+)
+
+var errInvalidLayout = errors.New("layout does not have one rectangle per connector")
+
+type MultiHeadOption func(*multiHeadConfig)
+
+type multiHeadConfig struct {
+	layout Layout
+	format PixelFormat
+}
+
+func WithLayout(l Layout) MultiHeadOption {
+	return func(c *multiHeadConfig) { c.layout = l }
+}
+
+func WithPixelFormat(f PixelFormat) MultiHeadOption {
+	return func(c *multiHeadConfig) { c.format = f }
+}
+
+type head struct {
+	fb        *Framebuffer
+	img       draw.Image
+	rect      image.Rectangle
+	savedCrtc mode.Crtc
+	crtcID    uint32
+	connID    uint32
+}
+
+type MultiHeadImage struct {
+	file   *os.File
+	heads  []head
+	bounds image.Rectangle
+}
+
+func NewMultiHeadImage(drmIndex int, opts ...MultiHeadOption) (*MultiHeadImage, error) {
+	cfg := multiHeadConfig{layout: Layout{Mode: LayoutSideBySide}, format: FormatBGR565}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	file, err := drm.OpenCard(drmIndex)
+	if err != nil {
+		return nil, fmt.Errorf("OpenCard(): %s", err.Error())
+	}
+
+	if !drm.HasDumbBuffer(file) {
+		file.Close()
+		return nil, fmt.Errorf("drm device does not support dumb buffers")
+	}
+
+	modeset, err := mode.NewSimpleModeset(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("NewSimpleModeset(): %s", err.Error())
+	}
+	if len(modeset.Modesets) == 0 {
+		file.Close()
+		return nil, errors.New("no connected outputs found")
+	}
+
+	sizes := make([]image.Point, len(modeset.Modesets))
+	for i, mod := range modeset.Modesets {
+		sizes[i] = image.Pt(int(mod.Width), int(mod.Height))
+	}
+	rects, err := cfg.layout.rects(sizes)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	heads := make([]head, 0, len(modeset.Modesets))
+	bounds := image.Rectangle{}
+	for i, mod := range modeset.Modesets {
+		framebuf, err := createFramebuffer(file, &mod, cfg.format)
+		if err != nil {
+			closeHeads(file, heads)
+			file.Close()
+			return nil, fmt.Errorf("failed to create framebuffer for connector %d: %s", mod.Conn, err.Error())
+		}
+
+		savedCrtc, err := mode.GetCrtc(file, mod.Crtc)
+		if err != nil {
+			framebuf.Close(file)
+			closeHeads(file, heads)
+			file.Close()
+			return nil, fmt.Errorf("cannot get CRTC for connector %d: %s", mod.Conn, err.Error())
+		}
+
+		if err := mode.SetCrtc(file, mod.Crtc, framebuf.id, 0, 0, &mod.Conn, 1, &mod.Mode); err != nil {
+			framebuf.Close(file)
+			closeHeads(file, heads)
+			file.Close()
+			return nil, fmt.Errorf("cannot set CRTC for connector %d: %s", mod.Conn, err.Error())
+		}
+
+		rect := rects[i]
+		heads = append(heads, head{
+			fb:        framebuf,
+			img:       framebuf.image(rect.Dx(), rect.Dy()),
+			rect:      rect,
+			savedCrtc: savedCrtc,
+			crtcID:    mod.Crtc,
+			connID:    mod.Conn,
+		})
+		bounds = bounds.Union(rect)
+	}
+
+	return &MultiHeadImage{file: file, heads: heads, bounds: bounds}, nil
+}
+
+func closeHeads(file *os.File, heads []head) {
+	for _, h := range heads {
+		mode.SetCrtc(file, h.crtcID, h.savedCrtc.FbID, h.savedCrtc.X, h.savedCrtc.Y, &h.connID, 1, &h.savedCrtc.Mode)
+		h.fb.Close(file)
+	}
+}
+
+// Close restores every head's saved CRTC, tears down its framebuffer,
+// and closes the shared DRM fd.
+func (m *MultiHeadImage) Close() error {
+	var firstErr error
+	for _, h := range m.heads {
+		if err := mode.SetCrtc(m.file, h.crtcID, h.savedCrtc.FbID, h.savedCrtc.X, h.savedCrtc.Y, &h.connID, 1, &h.savedCrtc.Mode); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to restore CRTC for connector %d: %s", h.connID, err.Error())
+		}
+		if err := h.fb.Close(m.file); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if err := m.file.Close(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+func (m *MultiHeadImage) Bounds() image.Rectangle { return m.bounds }
+func (m *MultiHeadImage) ColorModel() color.Model { return color.NRGBAModel }
+
+func (m *MultiHeadImage) headAt(x, y int) *head {
+	p := image.Pt(x, y)
+	for i := range m.heads {
+		if p.In(m.heads[i].rect) {
+			return &m.heads[i]
+		}
+	}
+	return nil
+}
+
+func (m *MultiHeadImage) At(x, y int) color.Color {
+	h := m.headAt(x, y)
+	if h == nil {
+		return color.NRGBA{}
+	}
+	r := h.rect
+	return h.img.At(x-r.Min.X, y-r.Min.Y)
+}
+
+func (m *MultiHeadImage) Set(x, y int, c color.Color) {
+	h := m.headAt(x, y)
+	if h == nil {
+		return
+	}
+	r := h.rect
+	h.img.Set(x-r.Min.X, y-r.Min.Y, c)
+}
+
+// Flush is a no-op placeholder today; once page-flip integration lands
+// for multi-head output, it will issue a PageFlip for every head.
+func (m *MultiHeadImage) Flush() error {
+	return nil
+}
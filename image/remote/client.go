@@ -0,0 +1,95 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"sync"
+
+	drmimage "github.com/zonque/drm/image"
+)
+
+// Client implements draw.Image over a remote FramebufferService: Set
+// writes into a local mirror of the buffer and is only sent to the
+// server in batches, on Flush.
+type Client struct {
+	rpc    FramebufferServiceClient
+	format drmimage.PixelFormat
+	stride int
+	pix    drmimage.RawImage
+
+	mu    sync.Mutex
+	dirty image.Rectangle
+}
+
+// NewClient queries rpc for the remote framebuffer's dimensions and
+// format and returns a Client ready to draw into.
+func NewClient(ctx context.Context, rpc FramebufferServiceClient) (*Client, error) {
+	info, err := rpc.GetInfo(ctx, &InfoRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("GetInfo(): %s", err.Error())
+	}
+
+	format := drmimage.PixelFormat(info.Format)
+	rect := image.Rect(0, 0, int(info.Width), int(info.Height))
+	buf := make([]uint8, int(info.Stride)*int(info.Height))
+
+	return &Client{
+		rpc:    rpc,
+		format: format,
+		stride: int(info.Stride),
+		pix:    drmimage.NewPixelImage(format, buf, int(info.Stride), rect),
+	}, nil
+}
+
+func (c *Client) Bounds() image.Rectangle { return c.pix.Bounds() }
+func (c *Client) ColorModel() color.Model { return c.pix.ColorModel() }
+func (c *Client) At(x, y int) color.Color { return c.pix.At(x, y) }
+
+func (c *Client) Set(x, y int, col color.Color) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pix.Set(x, y, col)
+	c.dirty = c.dirty.Union(image.Rect(x, y, x+1, y+1))
+}
+
+// Flush sends every pixel touched since the last Flush to the server in
+// a single SetRect call.
+func (c *Client) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	dirty := c.dirty
+	c.dirty = image.Rectangle{}
+	c.mu.Unlock()
+
+	if dirty.Empty() {
+		_, err := c.rpc.Flush(ctx, &Empty{})
+		return err
+	}
+
+	bpp := c.format.BytesPerPixel()
+	pix, stride := c.pix.Bytes()
+	rowLen := dirty.Dx() * bpp
+	data := make([]uint8, 0, rowLen*dirty.Dy())
+	for y := dirty.Min.Y; y < dirty.Max.Y; y++ {
+		off := y*stride + dirty.Min.X*bpp
+		data = append(data, pix[off:off+rowLen]...)
+	}
+
+	req := &SetRectRequest{
+		Rect: Rect{
+			X0: int32(dirty.Min.X),
+			Y0: int32(dirty.Min.Y),
+			X1: int32(dirty.Max.X),
+			Y1: int32(dirty.Max.Y),
+		},
+		Format: uint32(c.format),
+		Data:   data,
+	}
+	if _, err := c.rpc.SetRect(ctx, req); err != nil {
+		return fmt.Errorf("SetRect(): %s", err.Error())
+	}
+
+	_, err := c.rpc.Flush(ctx, &Empty{})
+	return err
+}
@@ -0,0 +1,33 @@
+package remote
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// gobCodec replaces grpc's default "proto" codec so the message types in
+// framebuffer.pb.go, which are plain structs rather than proto.Message
+// implementations, can still be marshaled. It registers under the same
+// "proto" name the default codec uses, so no changes are needed at the
+// Dial/NewServer call sites.
+type gobCodec struct{}
+
+func (gobCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+func (gobCodec) Name() string { return "proto" }
+
+func init() {
+	encoding.RegisterCodec(gobCodec{})
+}
@@ -0,0 +1,55 @@
+// Message types mirroring framebuffer.proto. They are hand-maintained
+// rather than run through protoc-gen-go, so they are plain structs and
+// do not implement proto.Message; codec.go registers a gob-based codec
+// so gRPC can marshal them without it.
+package remote
+
+type Point struct {
+	X int32
+	Y int32
+}
+
+type Rect struct {
+	X0 int32
+	Y0 int32
+	X1 int32
+	Y1 int32
+}
+
+type Color struct {
+	R uint32
+	G uint32
+	B uint32
+	A uint32
+}
+
+type SetPixelRequest struct {
+	Point Point
+	Color Color
+}
+
+type SetRectRequest struct {
+	Rect   Rect
+	Format uint32
+	Data   []byte
+}
+
+type FillRequest struct {
+	Rect  Rect
+	Color Color
+}
+
+type InfoRequest struct{}
+
+type Info struct {
+	Width  int32
+	Height int32
+	Stride int32
+	Format uint32
+}
+
+type DamageRect struct {
+	Rect Rect
+}
+
+type Empty struct{}
@@ -0,0 +1,235 @@
+// Client and server stubs for FramebufferService, mirroring the shape
+// protoc-gen-go-grpc would produce from framebuffer.proto. Hand-written
+// alongside framebuffer.pb.go; see codec.go for how these are marshaled
+// without proto.Message.
+package remote
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// FramebufferServiceClient is the client API for FramebufferService.
+type FramebufferServiceClient interface {
+	SetPixel(ctx context.Context, in *SetPixelRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetRect(ctx context.Context, in *SetRectRequest, opts ...grpc.CallOption) (*Empty, error)
+	Fill(ctx context.Context, in *FillRequest, opts ...grpc.CallOption) (*Empty, error)
+	Flush(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	GetInfo(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*Info, error)
+	Subscribe(ctx context.Context, in *Empty, opts ...grpc.CallOption) (FramebufferService_SubscribeClient, error)
+}
+
+type framebufferServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewFramebufferServiceClient wraps conn in a FramebufferServiceClient.
+func NewFramebufferServiceClient(conn grpc.ClientConnInterface) FramebufferServiceClient {
+	return &framebufferServiceClient{cc: conn}
+}
+
+func (c *framebufferServiceClient) SetPixel(ctx context.Context, in *SetPixelRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	if err := c.cc.Invoke(ctx, "/remote.FramebufferService/SetPixel", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *framebufferServiceClient) SetRect(ctx context.Context, in *SetRectRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	if err := c.cc.Invoke(ctx, "/remote.FramebufferService/SetRect", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *framebufferServiceClient) Fill(ctx context.Context, in *FillRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	if err := c.cc.Invoke(ctx, "/remote.FramebufferService/Fill", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *framebufferServiceClient) Flush(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	if err := c.cc.Invoke(ctx, "/remote.FramebufferService/Flush", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *framebufferServiceClient) GetInfo(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*Info, error) {
+	out := new(Info)
+	if err := c.cc.Invoke(ctx, "/remote.FramebufferService/GetInfo", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *framebufferServiceClient) Subscribe(ctx context.Context, in *Empty, opts ...grpc.CallOption) (FramebufferService_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FramebufferService_ServiceDesc.Streams[0], "/remote.FramebufferService/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &framebufferServiceSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// FramebufferService_SubscribeClient is the client-side stream of
+// damage rectangles returned by Subscribe.
+type FramebufferService_SubscribeClient interface {
+	Recv() (*DamageRect, error)
+	grpc.ClientStream
+}
+
+type framebufferServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *framebufferServiceSubscribeClient) Recv() (*DamageRect, error) {
+	m := new(DamageRect)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FramebufferServiceServer is the server API for FramebufferService.
+type FramebufferServiceServer interface {
+	SetPixel(context.Context, *SetPixelRequest) (*Empty, error)
+	SetRect(context.Context, *SetRectRequest) (*Empty, error)
+	Fill(context.Context, *FillRequest) (*Empty, error)
+	Flush(context.Context, *Empty) (*Empty, error)
+	GetInfo(context.Context, *InfoRequest) (*Info, error)
+	Subscribe(*Empty, FramebufferService_SubscribeServer) error
+}
+
+// FramebufferService_SubscribeServer is the server-side stream of
+// damage rectangles sent by Subscribe.
+type FramebufferService_SubscribeServer interface {
+	Send(*DamageRect) error
+	grpc.ServerStream
+}
+
+type framebufferServiceSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *framebufferServiceSubscribeServer) Send(m *DamageRect) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// RegisterFramebufferServiceServer registers srv on s.
+func RegisterFramebufferServiceServer(s grpc.ServiceRegistrar, srv FramebufferServiceServer) {
+	s.RegisterService(&FramebufferService_ServiceDesc, srv)
+}
+
+func _FramebufferService_SetPixel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPixelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FramebufferServiceServer).SetPixel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/remote.FramebufferService/SetPixel"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FramebufferServiceServer).SetPixel(ctx, req.(*SetPixelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FramebufferService_SetRect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FramebufferServiceServer).SetRect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/remote.FramebufferService/SetRect"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FramebufferServiceServer).SetRect(ctx, req.(*SetRectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FramebufferService_Fill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FramebufferServiceServer).Fill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/remote.FramebufferService/Fill"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FramebufferServiceServer).Fill(ctx, req.(*FillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FramebufferService_Flush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FramebufferServiceServer).Flush(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/remote.FramebufferService/Flush"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FramebufferServiceServer).Flush(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FramebufferService_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FramebufferServiceServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/remote.FramebufferService/GetInfo"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FramebufferServiceServer).GetInfo(ctx, req.(*InfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FramebufferService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FramebufferServiceServer).Subscribe(m, &framebufferServiceSubscribeServer{stream})
+}
+
+// FramebufferService_ServiceDesc is the grpc.ServiceDesc for
+// FramebufferService.
+var FramebufferService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "remote.FramebufferService",
+	HandlerType: (*FramebufferServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "SetPixel", Handler: _FramebufferService_SetPixel_Handler},
+		{MethodName: "SetRect", Handler: _FramebufferService_SetRect_Handler},
+		{MethodName: "Fill", Handler: _FramebufferService_Fill_Handler},
+		{MethodName: "Flush", Handler: _FramebufferService_Flush_Handler},
+		{MethodName: "GetInfo", Handler: _FramebufferService_GetInfo_Handler},
+	},
+	Streams: []grpc.StreamDesc{
+		{StreamName: "Subscribe", Handler: _FramebufferService_Subscribe_Handler, ServerStreams: true},
+	},
+	Metadata: "framebuffer.proto",
+}
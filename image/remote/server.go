@@ -0,0 +1,162 @@
+// Package remote serves a draw.Image backed by a DRM framebuffer over
+// gRPC, so a headless SBC can have its panel driven by a render
+// process running elsewhere.
+package remote
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+
+	drmimage "github.com/zonque/drm/image"
+)
+
+// damageBacklog bounds how many pending damage rects a slow Subscribe
+// client can fall behind by before it starts missing updates.
+const damageBacklog = 64
+
+// Server implements FramebufferServiceServer over an existing
+// draw.Image, typically one returned by drmimage.NewDRMImage. When img
+// also implements drmimage.RawImage and the request's format matches
+// the server's, SetRect memcpy's its payload straight into the backing
+// memory instead of going through Set() pixel by pixel.
+type Server struct {
+	img    draw.Image
+	format drmimage.PixelFormat
+	stride int
+
+	mu   sync.Mutex
+	subs map[chan *DamageRect]struct{}
+}
+
+// NewServer wraps img, which must have the given format and stride, as
+// a FramebufferServiceServer.
+func NewServer(img draw.Image, format drmimage.PixelFormat, stride int) *Server {
+	return &Server{
+		img:    img,
+		format: format,
+		stride: stride,
+		subs:   make(map[chan *DamageRect]struct{}),
+	}
+}
+
+func toColor(c Color) color.RGBA {
+	return color.RGBA{R: uint8(c.R), G: uint8(c.G), B: uint8(c.B), A: uint8(c.A)}
+}
+
+func (s *Server) SetPixel(ctx context.Context, req *SetPixelRequest) (*Empty, error) {
+	s.img.Set(int(req.Point.X), int(req.Point.Y), toColor(req.Color))
+	s.publish(&Rect{X0: req.Point.X, Y0: req.Point.Y, X1: req.Point.X + 1, Y1: req.Point.Y + 1})
+	return &Empty{}, nil
+}
+
+func (s *Server) SetRect(ctx context.Context, req *SetRectRequest) (*Empty, error) {
+	r := req.Rect
+	width := int(r.X1 - r.X0)
+	height := int(r.Y1 - r.Y0)
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid rect %v", r)
+	}
+
+	bounds := s.img.Bounds()
+	if int(r.X0) < bounds.Min.X || int(r.Y0) < bounds.Min.Y || int(r.X1) > bounds.Max.X || int(r.Y1) > bounds.Max.Y {
+		return nil, fmt.Errorf("rect %v is out of bounds %v", r, bounds)
+	}
+
+	if raw, ok := s.img.(drmimage.RawImage); ok && drmimage.PixelFormat(req.Format) == s.format {
+		pix, stride := raw.Bytes()
+		bpp := s.format.BytesPerPixel()
+		if len(req.Data) != width*height*bpp {
+			return nil, fmt.Errorf("SetRect payload is %d bytes, want %d for a %dx%d rect", len(req.Data), width*height*bpp, width, height)
+		}
+		rowLen := width * bpp
+		for y := 0; y < height; y++ {
+			srcOff := y * rowLen
+			dstOff := (int(r.Y0)+y)*stride + int(r.X0)*bpp
+			copy(pix[dstOff:dstOff+rowLen], req.Data[srcOff:srcOff+rowLen])
+		}
+	} else {
+		format := drmimage.PixelFormat(req.Format)
+		src := drmimage.NewPixelImage(format, req.Data, width*format.BytesPerPixel(), image.Rect(0, 0, width, height))
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				s.img.Set(int(r.X0)+x, int(r.Y0)+y, src.At(x, y))
+			}
+		}
+	}
+
+	s.publish(&r)
+	return &Empty{}, nil
+}
+
+func (s *Server) Fill(ctx context.Context, req *FillRequest) (*Empty, error) {
+	r := req.Rect
+	c := toColor(req.Color)
+	for y := r.Y0; y < r.Y1; y++ {
+		for x := r.X0; x < r.X1; x++ {
+			s.img.Set(int(x), int(y), c)
+		}
+	}
+	s.publish(&r)
+	return &Empty{}, nil
+}
+
+func (s *Server) Flush(ctx context.Context, req *Empty) (*Empty, error) {
+	return &Empty{}, nil
+}
+
+func (s *Server) GetInfo(ctx context.Context, req *InfoRequest) (*Info, error) {
+	b := s.img.Bounds()
+	return &Info{
+		Width:  int32(b.Dx()),
+		Height: int32(b.Dy()),
+		Stride: int32(s.stride),
+		Format: uint32(s.format),
+	}, nil
+}
+
+// Subscribe streams the damage rectangles reported by SetPixel,
+// SetRect and Fill until the client disconnects. A subscriber that
+// falls more than damageBacklog rectangles behind misses updates.
+func (s *Server) Subscribe(req *Empty, stream FramebufferService_SubscribeServer) error {
+	ch := make(chan *DamageRect, damageBacklog)
+
+	s.mu.Lock()
+	s.subs[ch] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.subs, ch)
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case d := <-ch:
+			if err := stream.Send(d); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func (s *Server) publish(r *Rect) {
+	d := &DamageRect{Rect: *r}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subs {
+		select {
+		case ch <- d:
+		default:
+			// Subscriber fell behind; drop the update rather than
+			// block the writer.
+		}
+	}
+}
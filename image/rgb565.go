@@ -0,0 +1,36 @@
+package image
+
+import (
+	"image"
+	"image/color"
+)
+
+type RGB565 struct {
+	Pix    []uint8
+	Stride int
+	Rect   image.Rectangle
+}
+
+func (p *RGB565) Bounds() image.Rectangle { return p.Rect }
+func (p *RGB565) ColorModel() color.Model { return color.NRGBAModel }
+func (p *RGB565) PixOffset(x, y int) int  { return y*p.Stride + x*2 }
+
+func (p *RGB565) Set(x, y int, c color.Color) {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return
+	}
+	i := p.PixOffset(x, y)
+	c1 := color.NRGBAModel.Convert(c).(color.NRGBA)
+	p.Pix[i+0] = (c1.B >> 3) | ((c1.G >> 2) << 5)
+	p.Pix[i+1] = (c1.G >> 5) | ((c1.R >> 3) << 3)
+}
+
+func (p *RGB565) At(x, y int) color.Color {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return color.NRGBA{}
+	}
+	i := p.PixOffset(x, y)
+	return color.NRGBA{(p.Pix[i+1] >> 3) << 3, (p.Pix[i+1] << 5) | ((p.Pix[i+0] >> 5) << 2), p.Pix[i+0] << 3, 255}
+}
+
+func (p *RGB565) Bytes() ([]uint8, int) { return p.Pix, p.Stride }
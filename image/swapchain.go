@@ -0,0 +1,137 @@
+package image
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image/draw"
+	"os"
+
+	"github.com/NeowayLabs/drm/mode"
+)
+
+// drmEventFlipComplete is DRM_EVENT_FLIP_COMPLETE from drm.h: the type
+// field of a drm_event struct read back off the DRM fd once a page flip
+// has been latched by the hardware at vblank.
+const drmEventFlipComplete = 0x02
+
+// drmEventHeaderSize is sizeof(struct drm_event): two uint32 fields,
+// "type" and "length".
+const drmEventHeaderSize = 8
+
+// SwapChain drives page-flipped double or triple buffering on a single
+// connector: it owns count Framebuffers, shows one of them via SetCrtc
+// and hands the caller the others to draw into, advancing the ring on
+// every Present().
+type SwapChain struct {
+	file    *os.File
+	crtc    uint32
+	conn    uint32
+	mode    mode.ModeInfo
+	width   int
+	height  int
+	buffers []*Framebuffer
+	front   int
+}
+
+// NewSwapChain allocates count dumb buffers for dev (2 for double, 3 for
+// triple buffering), shows the first one via SetCrtc and returns a
+// SwapChain ready for Present().
+func NewSwapChain(file *os.File, dev *mode.Modeset, count int, format PixelFormat) (*SwapChain, error) {
+	if count < 2 || count > 3 {
+		return nil, fmt.Errorf("swap chain requires 2 or 3 buffers, got %d", count)
+	}
+
+	buffers := make([]*Framebuffer, 0, count)
+	for i := 0; i < count; i++ {
+		fb, err := createFramebuffer(file, dev, format)
+		if err != nil {
+			for _, b := range buffers {
+				b.Close(file)
+			}
+			return nil, fmt.Errorf("failed to allocate swap chain buffer %d: %s", i, err.Error())
+		}
+		buffers = append(buffers, fb)
+	}
+
+	if err := mode.SetCrtc(file, dev.Crtc, buffers[0].id, 0, 0, &dev.Conn, 1, &dev.Mode); err != nil {
+		for _, b := range buffers {
+			b.Close(file)
+		}
+		return nil, fmt.Errorf("cannot set CRTC for connector %d: %s", dev.Conn, err.Error())
+	}
+
+	return &SwapChain{
+		file:    file,
+		crtc:    dev.Crtc,
+		conn:    dev.Conn,
+		mode:    dev.Mode,
+		width:   int(dev.Width),
+		height:  int(dev.Height),
+		buffers: buffers,
+		front:   0,
+	}, nil
+}
+
+// Back returns the buffer not currently shown on screen, for the
+// caller to draw the first frame into before any Present() has flipped.
+func (s *SwapChain) Back() draw.Image {
+	back := s.buffers[(s.front+1)%len(s.buffers)]
+	return back.image(s.width, s.height)
+}
+
+// Present flips the currently shown buffer out for the one the caller
+// has just finished drawing into, blocks until the kernel reports the
+// flip has been latched at vblank, and returns the new back buffer for
+// the caller to draw the next frame into.
+func (s *SwapChain) Present() (draw.Image, error) {
+	back := (s.front + 1) % len(s.buffers)
+
+	if err := mode.PageFlip(s.file, s.crtc, s.buffers[back].id, mode.PageFlipEvent); err != nil {
+		return nil, fmt.Errorf("page flip failed: %s", err.Error())
+	}
+
+	if err := s.waitForFlip(); err != nil {
+		return nil, err
+	}
+
+	s.front = back
+	next := s.buffers[(s.front+1)%len(s.buffers)]
+
+	return next.image(s.width, s.height), nil
+}
+
+// waitForFlip blocks on the DRM fd until it sees a flip-complete event,
+// discarding any other event types (e.g. vblank events not tied to a
+// flip) in between.
+func (s *SwapChain) waitForFlip() error {
+	buf := make([]byte, 1024)
+	for {
+		n, err := s.file.Read(buf)
+		if err != nil {
+			return fmt.Errorf("failed to read DRM event: %s", err.Error())
+		}
+
+		for off := 0; off+drmEventHeaderSize <= n; {
+			evType := binary.LittleEndian.Uint32(buf[off:])
+			evLen := binary.LittleEndian.Uint32(buf[off+4:])
+			if evLen == 0 {
+				break
+			}
+			if evType == drmEventFlipComplete {
+				return nil
+			}
+			off += int(evLen)
+		}
+	}
+}
+
+// Close tears down every buffer in the chain.
+func (s *SwapChain) Close() error {
+	var firstErr error
+	for _, b := range s.buffers {
+		if err := b.Close(s.file); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,92 @@
+package image
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"unsafe"
+
+	"github.com/NeowayLabs/drm"
+	"github.com/NeowayLabs/drm/mode"
+)
+
+// Linux VT ioctls and vt_mode constants (linux/vt.h), used to ask the
+// kernel to notify us via signal instead of switching the console out
+// from under a running KMS application.
+const (
+	vtGetMode = 0x5601
+	vtSetMode = 0x5602
+	vtRelDisp = 0x5605
+	vtAuto    = 0
+	vtProcess = 1
+	vtAckAcq  = 2
+)
+
+type vtMode struct {
+	Mode   int8
+	Waitv  int8
+	Relsig int16
+	Acqsig int16
+	Frsig  int16
+}
+
+func vtIoctl(fd uintptr, cmd uintptr, arg uintptr) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, cmd, arg)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// WatchVT arranges for the process to be notified via SIGUSR1/SIGUSR2
+// when the user switches virtual terminals, instead of having the
+// console switched out from under it. On release it drops DRM master
+// and restores the saved CRTC so getty/logind can use the console; on
+// re-acquisition it regains DRM master and re-applies the mode. Call
+// the returned stop function to undo this before Close().
+func (d *DRMImage) WatchVT() (stop func(), err error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open controlling tty: %s", err.Error())
+	}
+
+	vm := vtMode{Mode: vtProcess, Relsig: int16(syscall.SIGUSR1), Acqsig: int16(syscall.SIGUSR2)}
+	if err := vtIoctl(tty.Fd(), vtSetMode, uintptr(unsafe.Pointer(&vm))); err != nil {
+		tty.Close()
+		return nil, fmt.Errorf("VT_SETMODE failed: %s", err.Error())
+	}
+
+	sig := make(chan os.Signal, 2)
+	signal.Notify(sig, syscall.SIGUSR1, syscall.SIGUSR2)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case s := <-sig:
+				switch s {
+				case syscall.SIGUSR1:
+					mode.SetCrtc(d.file, d.crtcID, d.savedCrtc.FbID, d.savedCrtc.X, d.savedCrtc.Y, &d.connID, 1, &d.savedCrtc.Mode)
+					drm.DropMaster(d.file)
+					vtIoctl(tty.Fd(), vtRelDisp, 1)
+				case syscall.SIGUSR2:
+					drm.SetMaster(d.file)
+					mode.SetCrtc(d.file, d.crtcID, d.fb.id, 0, 0, &d.connID, 1, &d.appliedMode)
+					vtIoctl(tty.Fd(), vtRelDisp, vtAckAcq)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	stop = func() {
+		close(done)
+		signal.Stop(sig)
+		auto := vtMode{Mode: vtAuto}
+		vtIoctl(tty.Fd(), vtSetMode, uintptr(unsafe.Pointer(&auto)))
+		tty.Close()
+	}
+	return stop, nil
+}
@@ -0,0 +1,40 @@
+package image
+
+import (
+	"image"
+	"image/color"
+)
+
+// XRGB8888's padding byte is always written as 0xff and ignored on
+// read, i.e. the image is always fully opaque.
+type XRGB8888 struct {
+	Pix    []uint8
+	Stride int
+	Rect   image.Rectangle
+}
+
+func (p *XRGB8888) Bounds() image.Rectangle { return p.Rect }
+func (p *XRGB8888) ColorModel() color.Model { return color.RGBAModel }
+func (p *XRGB8888) PixOffset(x, y int) int  { return y*p.Stride + x*4 }
+
+func (p *XRGB8888) Set(x, y int, c color.Color) {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return
+	}
+	i := p.PixOffset(x, y)
+	c1 := color.RGBAModel.Convert(c).(color.RGBA)
+	p.Pix[i+0] = c1.B
+	p.Pix[i+1] = c1.G
+	p.Pix[i+2] = c1.R
+	p.Pix[i+3] = 0xff
+}
+
+func (p *XRGB8888) At(x, y int) color.Color {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return color.RGBA{}
+	}
+	i := p.PixOffset(x, y)
+	return color.RGBA{p.Pix[i+2], p.Pix[i+1], p.Pix[i+0], 0xff}
+}
+
+func (p *XRGB8888) Bytes() ([]uint8, int) { return p.Pix, p.Stride }